@@ -0,0 +1,178 @@
+package sqlx
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Bind types supported by Rebind, mirroring the placeholder styles of the
+// common SQL drivers.
+const (
+	UNKNOWN = iota
+	QUESTION
+	DOLLAR
+	NAMED
+	AT
+)
+
+// BindType returns the bindtype for a given database given a drivername.
+func BindType(driverName string) int {
+	switch driverName {
+	case "postgres", "pgx", "pq-timeouts", "cloudsqlpostgres":
+		return DOLLAR
+	case "mysql", "sqlite3":
+		return QUESTION
+	case "oci8", "ora", "goracle", "godror":
+		return NAMED
+	case "sqlserver":
+		return AT
+	}
+	return UNKNOWN
+}
+
+// Rebind a query from the default `?` bindtype to the target bindtype.
+func Rebind(bindType int, query string) string {
+	switch bindType {
+	case QUESTION, UNKNOWN:
+		return query
+	}
+
+	qb := []byte(query)
+	rqb := make([]byte, 0, len(qb)+10)
+	var j, n int
+
+	for i := 0; i < len(qb); i++ {
+		if qb[i] != '?' {
+			rqb = append(rqb, qb[i])
+			continue
+		}
+
+		switch bindType {
+		case DOLLAR:
+			rqb = append(rqb, '$')
+		case NAMED:
+			rqb = append(rqb, ':', 'a', 'r', 'g')
+		case AT:
+			rqb = append(rqb, '@', 'p')
+		}
+
+		n++
+		rqb = strconv.AppendInt(rqb, int64(n), 10)
+		j++
+	}
+
+	return string(rqb)
+}
+
+// SetDriverName overrides the driver name Rebind and BindNamed use to pick a
+// bindtype. Open always records "mysql" since the underlying kratos DB is
+// MySQL-only; call SetDriverName when proxying to, or testing against, a
+// database that expects a different placeholder style.
+func (db *DB) SetDriverName(name string) *DB {
+	db.driverName = name
+	return db
+}
+
+// Rebind a query from the default `?` bindtype to this DB's bindtype.
+func (db *DB) Rebind(query string) string {
+	return Rebind(BindType(db.driverName), query)
+}
+
+// BindNamed binds a named query using this DB's bindtype and Mapper,
+// returning the rewritten query for this DB's driver and the ordered args.
+func (db *DB) BindNamed(query string, arg interface{}) (string, []interface{}, error) {
+	q, args, err := bindNamed(db.Mapper, query, arg)
+	if err != nil {
+		return "", nil, err
+	}
+	return Rebind(BindType(db.driverName), q), args, nil
+}
+
+// In expands slice values in args, returning the modified query string and a
+// new arg list that can be executed by a driver. In expands every `?` that
+// is bound to a slice or array arg into a sequence of `?,?,?...`, with the
+// slice's elements flattened into the returned arg list.  Types
+// implementing driver.Valuer are treated as scalars and not expanded.
+func In(query string, args ...interface{}) (string, []interface{}, error) {
+	flatArgs := make([][]interface{}, 0, len(args))
+	for _, arg := range args {
+		vals, isSlice := expandArg(arg)
+		if !isSlice {
+			flatArgs = append(flatArgs, []interface{}{arg})
+			continue
+		}
+		if len(vals) == 0 {
+			return "", nil, errors.New("sqlx: empty slice passed to 'in' query")
+		}
+		flatArgs = append(flatArgs, vals)
+	}
+
+	var buf strings.Builder
+	var argIndex int
+	var newArgs []interface{}
+	inQuote := false
+
+	qb := []byte(query)
+	for i := 0; i < len(qb); i++ {
+		c := qb[i]
+		if c == '\'' {
+			inQuote = !inQuote
+			buf.WriteByte(c)
+			continue
+		}
+		if c != '?' || inQuote {
+			buf.WriteByte(c)
+			continue
+		}
+
+		if argIndex >= len(flatArgs) {
+			return "", nil, errors.New("sqlx: number of bindVars exceeds arguments")
+		}
+		vals := flatArgs[argIndex]
+		argIndex++
+
+		for i, v := range vals {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteByte('?')
+			newArgs = append(newArgs, v)
+		}
+	}
+
+	if argIndex < len(flatArgs) {
+		return "", nil, errors.New("sqlx: number of bindVars less than number arguments")
+	}
+
+	return buf.String(), newArgs, nil
+}
+
+// expandArg flattens arg into its elements if it is a slice or array and
+// does not implement driver.Valuer. It returns ok=false for scalar args.
+func expandArg(arg interface{}) (vals []interface{}, ok bool) {
+	if _, isValuer := arg.(driver.Valuer); isValuer {
+		return nil, false
+	}
+
+	v := reflect.ValueOf(arg)
+	if !v.IsValid() {
+		return nil, false
+	}
+	t := v.Type()
+	if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+		return nil, false
+	}
+	// []byte is passed through to the driver as a scalar.
+	if t.Elem().Kind() == reflect.Uint8 {
+		return nil, false
+	}
+
+	vals = make([]interface{}, v.Len())
+	for i := range vals {
+		vals[i] = v.Index(i).Interface()
+	}
+	return vals, true
+}
@@ -0,0 +1,135 @@
+package sqlx
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestBindType(t *testing.T) {
+	cases := []struct {
+		driverName string
+		want       int
+	}{
+		{"mysql", QUESTION},
+		{"sqlite3", QUESTION},
+		{"postgres", DOLLAR},
+		{"pgx", DOLLAR},
+		{"oci8", NAMED},
+		{"godror", NAMED},
+		{"sqlserver", AT},
+		{"made-up-driver", UNKNOWN},
+	}
+
+	for _, c := range cases {
+		if got := BindType(c.driverName); got != c.want {
+			t.Errorf("BindType(%q) = %d, want %d", c.driverName, got, c.want)
+		}
+	}
+}
+
+func TestRebind(t *testing.T) {
+	query := "SELECT * FROM foo WHERE a=? AND b=? AND c=?"
+
+	cases := []struct {
+		name     string
+		bindType int
+		want     string
+	}{
+		{"question is a no-op", QUESTION, query},
+		{"unknown is a no-op", UNKNOWN, query},
+		{"dollar", DOLLAR, "SELECT * FROM foo WHERE a=$1 AND b=$2 AND c=$3"},
+		{"named", NAMED, "SELECT * FROM foo WHERE a=:arg1 AND b=:arg2 AND c=:arg3"},
+		{"at", AT, "SELECT * FROM foo WHERE a=@p1 AND b=@p2 AND c=@p3"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := Rebind(c.bindType, query); got != c.want {
+				t.Errorf("Rebind = %q, want %q", got, c.want)
+			}
+		})
+	}
+}
+
+func TestInExpandsSlice(t *testing.T) {
+	query, args, err := In("SELECT * FROM foo WHERE id IN (?) AND active = ?", []int{1, 2, 3}, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantQuery := "SELECT * FROM foo WHERE id IN (?,?,?) AND active = ?"
+	if query != wantQuery {
+		t.Errorf("query = %q, want %q", query, wantQuery)
+	}
+
+	want := []interface{}{1, 2, 3, true}
+	if !reflect.DeepEqual(args, want) {
+		t.Errorf("args = %v, want %v", args, want)
+	}
+}
+
+func TestInQuotedQuestionMarkIsNotABindVar(t *testing.T) {
+	query, args, err := In("SELECT * FROM foo WHERE name = '?' AND id IN (?)", []int{1, 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantQuery := "SELECT * FROM foo WHERE name = '?' AND id IN (?,?)"
+	if query != wantQuery {
+		t.Errorf("query = %q, want %q", query, wantQuery)
+	}
+
+	want := []interface{}{1, 2}
+	if !reflect.DeepEqual(args, want) {
+		t.Errorf("args = %v, want %v", args, want)
+	}
+}
+
+// valuerSlice implements driver.Valuer so In must treat it as a scalar even
+// though its underlying kind is a slice.
+type valuerSlice []int
+
+func (v valuerSlice) Value() (driver.Value, error) {
+	return fmt.Sprint([]int(v)), nil
+}
+
+func TestInDoesNotExpandValuer(t *testing.T) {
+	arg := valuerSlice{1, 2, 3}
+
+	query, args, err := In("SELECT * FROM foo WHERE ids = ?", arg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantQuery := "SELECT * FROM foo WHERE ids = ?"
+	if query != wantQuery {
+		t.Errorf("query = %q, want %q", query, wantQuery)
+	}
+
+	if len(args) != 1 || !reflect.DeepEqual(args[0], arg) {
+		t.Errorf("args = %v, want [%v] unexpanded", args, arg)
+	}
+}
+
+func TestInTooFewBindVars(t *testing.T) {
+	_, _, err := In("SELECT * FROM foo WHERE id = ?", 1, 2)
+	if err == nil {
+		t.Fatal("expected error when args outnumber bindvars, got nil")
+	}
+}
+
+func TestInTooManyBindVars(t *testing.T) {
+	_, _, err := In("SELECT * FROM foo WHERE id = ? AND name = ?", 1)
+	if err == nil {
+		t.Fatal("expected error when bindvars outnumber args, got nil")
+	}
+}
+
+func TestInEmptySlice(t *testing.T) {
+	_, _, err := In("SELECT * FROM foo WHERE id IN (?)", []int{})
+	if err == nil {
+		t.Fatal("expected error for empty slice arg, got nil")
+	}
+}
@@ -0,0 +1,70 @@
+package sqlx
+
+import (
+	"context"
+	sql "database/sql"
+	"os"
+	"path/filepath"
+)
+
+// Execer can Exec a query with the given args. *DB implements it directly;
+// *Tx's Exec has no ctx parameter, so wrap it in TxExecer to use it here.
+type Execer interface {
+	Exec(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// TxExecer adapts a *Tx to Execer. Tx.Exec takes no context (it runs within
+// an already-started transaction), so ctx is accepted and ignored.
+type TxExecer struct {
+	*Tx
+}
+
+// Exec implements Execer by delegating to the wrapped Tx, ignoring ctx.
+func (t TxExecer) Exec(_ context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return t.Tx.Exec(query, args...)
+}
+
+// LoadFile reads the file at path and executes its entire contents as a
+// single statement against e, which may be a *DB or a *Tx wrapped in
+// TxExecer.  Callers that need to run multiple statements must split the
+// file themselves before calling LoadFile.
+func LoadFile(ctx context.Context, e Execer, path string) (sql.Result, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	contents, err := os.ReadFile(abs)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.Exec(ctx, string(contents))
+}
+
+// MustExec execs the query using db and panics on error.
+func (db *DB) MustExec(ctx context.Context, query string, args ...interface{}) sql.Result {
+	res, err := db.Exec(ctx, query, args...)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// MustBegin starts a transaction and panics on error.
+func (db *DB) MustBegin(ctx context.Context) *Tx {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return tx
+}
+
+// MustExec execs the query within the transaction and panics on error.
+func (tx *Tx) MustExec(query string, args ...interface{}) sql.Result {
+	res, err := tx.Exec(query, args...)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
@@ -0,0 +1,290 @@
+package sqlx
+
+import (
+	"context"
+	sql "database/sql"
+	"fmt"
+	"reflect"
+
+	"github.com/weisd/kratos-sqlx/reflectx"
+)
+
+// NamedStmt is a prepared statement that executes named queries.  Prepare it
+// how you would execute a NamedQuery, but pass in a struct or map when
+// executing.
+type NamedStmt struct {
+	Params      []string
+	QueryString string
+	Stmt        *Stmt
+}
+
+// Close closes the named statement.
+func (n *NamedStmt) Close() error {
+	return n.Stmt.Close()
+}
+
+// Exec executes a named statement using the struct or map passed in as arg.
+func (n *NamedStmt) Exec(ctx context.Context, arg interface{}) (sql.Result, error) {
+	args, err := bindArgs(n.Stmt.Mapper, n.Params, arg)
+	if err != nil {
+		return nil, err
+	}
+	return n.Stmt.Exec(ctx, args...)
+}
+
+// Query executes a named statement using the struct or map passed in as arg,
+// returning the result rows.
+func (n *NamedStmt) Query(ctx context.Context, arg interface{}) (*Rows, error) {
+	args, err := bindArgs(n.Stmt.Mapper, n.Params, arg)
+	if err != nil {
+		return nil, err
+	}
+	return n.Stmt.Query(ctx, args...)
+}
+
+// QueryRow executes a named statement using the struct or map passed in as
+// arg, returning a single row.
+func (n *NamedStmt) QueryRow(ctx context.Context, arg interface{}) *Row {
+	args, err := bindArgs(n.Stmt.Mapper, n.Params, arg)
+	if err != nil {
+		return &Row{err: err}
+	}
+	return n.Stmt.QueryRow(ctx, args...)
+}
+
+// PrepareNamed returns a NamedStmt for the given query on this DB, binding
+// `:name` placeholders against the Mapper of db.
+func (db *DB) PrepareNamed(query string) (*NamedStmt, error) {
+	bound, names, err := compileNamedQuery([]byte(query))
+	if err != nil {
+		return nil, err
+	}
+	stmt, err := db.Prepare(bound)
+	if err != nil {
+		return nil, err
+	}
+	return &NamedStmt{Params: names, QueryString: bound, Stmt: stmt}, nil
+}
+
+// NamedQuery using this DB.
+// Any named placeholder parameters are replaced with fields from arg, which
+// may be a map[string]interface{} or a struct (or pointer to either).
+func (db *DB) NamedQuery(ctx context.Context, query string, arg interface{}) (*Rows, error) {
+	q, args, err := bindNamed(db.Mapper, query, arg)
+	if err != nil {
+		return nil, err
+	}
+	return db.Query(ctx, q, args...)
+}
+
+// NamedExec using this DB.
+// Any named placeholder parameters are replaced with fields from arg.  If arg
+// is a slice of structs or maps, the query is executed once per element.
+func (db *DB) NamedExec(ctx context.Context, query string, arg interface{}) (sql.Result, error) {
+	return namedExec(ctx, db.Mapper, query, arg, func(q string, args []interface{}) (sql.Result, error) {
+		return db.Exec(ctx, q, args...)
+	})
+}
+
+// NamedQuery using this Tx.
+// Any named placeholder parameters are replaced with fields from arg.
+func (tx *Tx) NamedQuery(query string, arg interface{}) (*Rows, error) {
+	q, args, err := bindNamed(tx.Mapper, query, arg)
+	if err != nil {
+		return nil, err
+	}
+	return tx.Query(q, args...)
+}
+
+// NamedExec using this Tx.
+// Any named placeholder parameters are replaced with fields from arg.  If arg
+// is a slice of structs or maps, the query is executed once per element.
+func (tx *Tx) NamedExec(query string, arg interface{}) (sql.Result, error) {
+	return namedExec(context.Background(), tx.Mapper, query, arg, func(q string, args []interface{}) (sql.Result, error) {
+		return tx.Exec(q, args...)
+	})
+}
+
+// BindNamed binds a named query using the default mapper, returning the
+// rewritten `?`-placeholder query and the ordered argument list.
+func BindNamed(query string, arg interface{}) (string, []interface{}, error) {
+	return bindNamed(mapper(), query, arg)
+}
+
+// namedExec runs a named query for a single arg, or once per element if arg
+// is a slice.  For a single arg it returns that execution's result; for a
+// slice it returns a combined result whose LastInsertId is the last one
+// reported and whose RowsAffected is the sum across all executions.
+func namedExec(ctx context.Context, m *reflectx.Mapper, query string, arg interface{}, exec func(string, []interface{}) (sql.Result, error)) (sql.Result, error) {
+	v := reflect.ValueOf(arg)
+	if k := reflectx.Deref(reflect.TypeOf(arg)).Kind(); k != reflect.Slice && k != reflect.Array {
+		q, args, err := bindNamed(m, query, arg)
+		if err != nil {
+			return nil, err
+		}
+		return exec(q, args)
+	}
+
+	var total multiResult
+	for i := 0; i < v.Len(); i++ {
+		q, args, err := bindNamed(m, query, v.Index(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		res, err := exec(q, args)
+		if err != nil {
+			return nil, err
+		}
+		if err := total.add(res); err != nil {
+			return nil, err
+		}
+	}
+	return &total, nil
+}
+
+// multiResult aggregates sql.Result across a batch of executions, keeping
+// the last insert id and summing rows affected.
+type multiResult struct {
+	lastInsertID int64
+	rowsAffected int64
+}
+
+func (m *multiResult) add(res sql.Result) error {
+	id, err := res.LastInsertId()
+	if err == nil {
+		m.lastInsertID = id
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	m.rowsAffected += n
+	return nil
+}
+
+// LastInsertId returns the last insert id of the last execution in the batch.
+func (m *multiResult) LastInsertId() (int64, error) {
+	return m.lastInsertID, nil
+}
+
+// RowsAffected returns the total rows affected across the batch.
+func (m *multiResult) RowsAffected() (int64, error) {
+	return m.rowsAffected, nil
+}
+
+// bindNamed rewrites a `:name` query into a `?`-placeholder query using m to
+// resolve names against arg.
+func bindNamed(m *reflectx.Mapper, query string, arg interface{}) (string, []interface{}, error) {
+	bound, names, err := compileNamedQuery([]byte(query))
+	if err != nil {
+		return "", nil, err
+	}
+	args, err := bindArgs(m, names, arg)
+	if err != nil {
+		return "", nil, err
+	}
+	return bound, args, nil
+}
+
+// bindArgs resolves names against arg, which may be a map[string]interface{},
+// a struct, or a pointer to either.
+func bindArgs(m *reflectx.Mapper, names []string, arg interface{}) ([]interface{}, error) {
+	if mp, ok := arg.(map[string]interface{}); ok {
+		return bindMapArgs(names, mp)
+	}
+
+	v := reflect.ValueOf(arg)
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Map {
+		if mp, ok := v.Interface().(map[string]interface{}); ok {
+			return bindMapArgs(names, mp)
+		}
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("sqlx: bindArgs expected map or struct, got %T", arg)
+	}
+
+	traversals := m.TraversalsByName(v.Type(), names)
+	args := make([]interface{}, 0, len(names))
+	for i, t := range traversals {
+		if len(t) == 0 {
+			return nil, fmt.Errorf("sqlx: could not find name %q in %T", names[i], arg)
+		}
+		args = append(args, reflectx.FieldByIndexes(v, t).Interface())
+	}
+	return args, nil
+}
+
+func bindMapArgs(names []string, arg map[string]interface{}) ([]interface{}, error) {
+	args := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		val, ok := arg[name]
+		if !ok {
+			return nil, fmt.Errorf("sqlx: could not find name %q in map", name)
+		}
+		args = append(args, val)
+	}
+	return args, nil
+}
+
+// compileNamedQuery rewrites `:name` tokens in qs into `?` placeholders in
+// order, skipping single-quoted string literals and `::` type casts, and
+// returns the rewritten query together with the names in the order they
+// appeared.
+func compileNamedQuery(qs []byte) (string, []string, error) {
+	out := make([]byte, 0, len(qs))
+	var names []string
+
+	inQuote := false
+	for i := 0; i < len(qs); i++ {
+		c := qs[i]
+
+		if c == '\'' {
+			inQuote = !inQuote
+			out = append(out, c)
+			continue
+		}
+		if inQuote {
+			out = append(out, c)
+			continue
+		}
+
+		if c == ':' {
+			// `::` is a type cast (e.g. Postgres), not a named parameter.
+			if i+1 < len(qs) && qs[i+1] == ':' {
+				out = append(out, ':', ':')
+				i++
+				continue
+			}
+
+			j := i + 1
+			for j < len(qs) && (isNameByte(qs[j])) {
+				j++
+			}
+			if j == i+1 {
+				// lone ':' with nothing following, leave as-is
+				out = append(out, c)
+				continue
+			}
+			names = append(names, string(qs[i+1:j]))
+			out = append(out, '?')
+			i = j - 1
+			continue
+		}
+
+		out = append(out, c)
+	}
+
+	if inQuote {
+		return "", nil, fmt.Errorf("sqlx: unterminated quote in query")
+	}
+
+	return string(out), names, nil
+}
+
+func isNameByte(c byte) bool {
+	return c == '_' || c == '.' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,36 @@
+package sqlx
+
+import (
+	"context"
+	sql "database/sql"
+)
+
+// NamedInQuery binds named parameters against arg and then expands any
+// slice-valued parameters with In, so a query such as
+// `WHERE tenant_id = :tenant AND id IN (:ids)` works with a []int64 :ids.
+// It delegates to db.Query (rather than the raw driver) so master/slave
+// routing, e.g. via db.Master(), still applies.
+func (db *DB) NamedInQuery(ctx context.Context, query string, arg interface{}) (*Rows, error) {
+	q, args, err := bindNamed(db.Mapper, query, arg)
+	if err != nil {
+		return nil, err
+	}
+	q, args, err = In(q, args...)
+	if err != nil {
+		return nil, err
+	}
+	return db.Query(ctx, q, args...)
+}
+
+// NamedInExec behaves like NamedInQuery, but executes the query with db.Exec.
+func (db *DB) NamedInExec(ctx context.Context, query string, arg interface{}) (sql.Result, error) {
+	q, args, err := bindNamed(db.Mapper, query, arg)
+	if err != nil {
+		return nil, err
+	}
+	q, args, err = In(q, args...)
+	if err != nil {
+		return nil, err
+	}
+	return db.Exec(ctx, q, args...)
+}
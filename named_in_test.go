@@ -0,0 +1,74 @@
+package sqlx
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestNamedInPipelineStructArgs exercises the same bindNamed+In pipeline
+// NamedInQuery/NamedInExec run, mixing a scalar and a slice-valued named
+// parameter sourced from struct fields.
+func TestNamedInPipelineStructArgs(t *testing.T) {
+	type Filter struct {
+		Tenant int     `db:"tenant"`
+		IDs    []int64 `db:"ids"`
+	}
+
+	f := Filter{Tenant: 9, IDs: []int64{1, 2, 3}}
+	query := "SELECT * FROM widgets WHERE tenant_id = :tenant AND id IN (:ids)"
+
+	bound, args, err := BindNamed(query, f)
+	if err != nil {
+		t.Fatalf("BindNamed: %v", err)
+	}
+
+	wantBound := "SELECT * FROM widgets WHERE tenant_id = ? AND id IN (?)"
+	if bound != wantBound {
+		t.Fatalf("bound query = %q, want %q", bound, wantBound)
+	}
+
+	expanded, inArgs, err := In(bound, args...)
+	if err != nil {
+		t.Fatalf("In: %v", err)
+	}
+
+	wantExpanded := "SELECT * FROM widgets WHERE tenant_id = ? AND id IN (?,?,?)"
+	if expanded != wantExpanded {
+		t.Errorf("expanded query = %q, want %q", expanded, wantExpanded)
+	}
+
+	wantArgs := []interface{}{9, int64(1), int64(2), int64(3)}
+	if !reflect.DeepEqual(inArgs, wantArgs) {
+		t.Errorf("args = %v, want %v", inArgs, wantArgs)
+	}
+}
+
+// TestNamedInPipelineMapArgs mixes a scalar and a slice-valued named
+// parameter sourced from a map[string]interface{} arg.
+func TestNamedInPipelineMapArgs(t *testing.T) {
+	arg := map[string]interface{}{
+		"tenant": 9,
+		"ids":    []int{1, 2},
+	}
+	query := "SELECT * FROM widgets WHERE tenant_id = :tenant AND id IN (:ids)"
+
+	bound, args, err := BindNamed(query, arg)
+	if err != nil {
+		t.Fatalf("BindNamed: %v", err)
+	}
+
+	expanded, inArgs, err := In(bound, args...)
+	if err != nil {
+		t.Fatalf("In: %v", err)
+	}
+
+	wantExpanded := "SELECT * FROM widgets WHERE tenant_id = ? AND id IN (?,?)"
+	if expanded != wantExpanded {
+		t.Errorf("expanded query = %q, want %q", expanded, wantExpanded)
+	}
+
+	wantArgs := []interface{}{9, 1, 2}
+	if !reflect.DeepEqual(inArgs, wantArgs) {
+		t.Errorf("args = %v, want %v", inArgs, wantArgs)
+	}
+}
@@ -0,0 +1,155 @@
+package sqlx
+
+import (
+	"context"
+	sql "database/sql"
+	"reflect"
+	"testing"
+)
+
+func TestCompileNamedQuery(t *testing.T) {
+	cases := []struct {
+		name  string
+		query string
+		want  string
+		names []string
+	}{
+		{
+			name:  "simple",
+			query: "SELECT * FROM foo WHERE name=:name AND age=:age",
+			want:  "SELECT * FROM foo WHERE name=? AND age=?",
+			names: []string{"name", "age"},
+		},
+		{
+			name:  "colon inside a quoted literal is left alone",
+			query: "SELECT * FROM foo WHERE name=:name AND note='a:b'",
+			want:  "SELECT * FROM foo WHERE name=? AND note='a:b'",
+			names: []string{"name"},
+		},
+		{
+			name:  "double colon cast is not a bind var",
+			query: "SELECT id::text FROM foo WHERE id=:id",
+			want:  "SELECT id::text FROM foo WHERE id=?",
+			names: []string{"id"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, names, err := compileNamedQuery([]byte(c.query))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != c.want {
+				t.Errorf("query = %q, want %q", got, c.want)
+			}
+			if !reflect.DeepEqual(names, c.names) {
+				t.Errorf("names = %v, want %v", names, c.names)
+			}
+		})
+	}
+}
+
+func TestBindNamedStructEmbedded(t *testing.T) {
+	type Address struct {
+		City string `db:"city"`
+	}
+	type Person struct {
+		Address
+		Name string `db:"name"`
+		ID   int    `db:"id"`
+	}
+
+	p := Person{Address: Address{City: "Shanghai"}, Name: "Li", ID: 7}
+
+	query := "SELECT * FROM person WHERE id=:id AND name=:name AND city=:city"
+	bound, args, err := BindNamed(query, p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantQuery := "SELECT * FROM person WHERE id=? AND name=? AND city=?"
+	if bound != wantQuery {
+		t.Errorf("query = %q, want %q", bound, wantQuery)
+	}
+
+	want := []interface{}{7, "Li", "Shanghai"}
+	if !reflect.DeepEqual(args, want) {
+		t.Errorf("args = %v, want %v", args, want)
+	}
+}
+
+func TestBindNamedMap(t *testing.T) {
+	query := "SELECT * FROM person WHERE id=:id AND name=:name"
+	arg := map[string]interface{}{"id": 7, "name": "Li"}
+
+	bound, args, err := BindNamed(query, arg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantQuery := "SELECT * FROM person WHERE id=? AND name=?"
+	if bound != wantQuery {
+		t.Errorf("query = %q, want %q", bound, wantQuery)
+	}
+
+	want := []interface{}{7, "Li"}
+	if !reflect.DeepEqual(args, want) {
+		t.Errorf("args = %v, want %v", args, want)
+	}
+}
+
+func TestBindNamedMapMissingKey(t *testing.T) {
+	_, _, err := BindNamed("SELECT * FROM person WHERE id=:id", map[string]interface{}{})
+	if err == nil {
+		t.Fatal("expected error for missing map key, got nil")
+	}
+}
+
+func TestBindNamedStructMissingField(t *testing.T) {
+	type Person struct {
+		Name string `db:"name"`
+	}
+	_, _, err := BindNamed("SELECT * FROM person WHERE missing=:missing", Person{Name: "Li"})
+	if err == nil {
+		t.Fatal("expected error for missing struct field, got nil")
+	}
+}
+
+// driverResult is a minimal sql.Result used to exercise namedExec without a
+// live database.
+type driverResult struct {
+	id, rows int64
+}
+
+func (d driverResult) LastInsertId() (int64, error) { return d.id, nil }
+func (d driverResult) RowsAffected() (int64, error) { return d.rows, nil }
+
+func TestNamedExecBatch(t *testing.T) {
+	type Person struct {
+		Name string `db:"name"`
+	}
+	people := []Person{{Name: "A"}, {Name: "B"}, {Name: "C"}}
+
+	var queries []string
+	exec := func(q string, args []interface{}) (sql.Result, error) {
+		queries = append(queries, q)
+		return driverResult{rows: 1}, nil
+	}
+
+	res, err := namedExec(context.Background(), mapper(), "INSERT INTO person(name) VALUES(:name)", people, exec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(queries) != 3 {
+		t.Errorf("exec called %d times, want 3", len(queries))
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		t.Fatalf("RowsAffected: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("RowsAffected = %d, want 3", n)
+	}
+}
@@ -302,3 +302,52 @@ func structOnlyError(t reflect.Type) error {
 	}
 	return fmt.Errorf("expected a struct, but struct %s has no exported fields", t.Name())
 }
+
+// rowScanner is satisfied by both Rows and Row.
+type rowScanner interface {
+	Columns() ([]string, error)
+	Scan(...interface{}) error
+}
+
+// MapScanText scans the current row into dest, keyed by column name, and
+// converts []byte column values to string. The conversion always applies;
+// there is no map scan that keeps raw []byte values.
+func (r *Rows) MapScanText(dest map[string]interface{}) error {
+	return mapScanText(r, dest)
+}
+
+// MapScanText scans the single row into dest, keyed by column name, and
+// converts []byte column values to string. The conversion always applies;
+// there is no map scan that keeps raw []byte values.
+func (row *Row) MapScanText(dest map[string]interface{}) error {
+	if row.err != nil {
+		return row.err
+	}
+	return mapScanText(row, dest)
+}
+
+func mapScanText(r rowScanner, dest map[string]interface{}) error {
+	columns, err := r.Columns()
+	if err != nil {
+		return err
+	}
+
+	values := make([]interface{}, len(columns))
+	for i := range values {
+		values[i] = new(interface{})
+	}
+
+	if err := r.Scan(values...); err != nil {
+		return err
+	}
+
+	for i, column := range columns {
+		v := *(values[i].(*interface{}))
+		if b, ok := v.([]byte); ok {
+			v = string(b)
+		}
+		dest[column] = v
+	}
+
+	return nil
+}